@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// LoadOwner populates m.Owner with the user that submitted the task, if not already loaded.
+// Returns db.ErrNotFound if the owner (e.g. a deleted user) no longer exists.
+func (m *TaskMetadata) LoadOwner(ctx context.Context) error {
+	if m.Owner != nil {
+		return nil
+	}
+
+	var owner model.User
+	if err := db.Bun().NewSelect().Model(&owner).
+		Where("id = ?", m.OwnerID).
+		Scan(ctx); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return db.ErrNotFound
+		}
+		return err
+	}
+	m.Owner = &owner
+	return nil
+}
+
+// LoadWorkspace populates m.Workspace with the task's workspace, if not already loaded.
+// Returns db.ErrNotFound if the workspace (e.g. a deleted workspace) no longer exists.
+func (m *TaskMetadata) LoadWorkspace(ctx context.Context) error {
+	if m.Workspace != nil {
+		return nil
+	}
+
+	var workspace model.Workspace
+	if err := db.Bun().NewSelect().Model(&workspace).
+		Where("id = ?", m.WorkspaceID).
+		Scan(ctx); err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return db.ErrNotFound
+		}
+		return err
+	}
+	m.Workspace = &workspace
+	return nil
+}
+
+// LoadExperiments populates m.Experiments with the experiments referenced by the task, if not
+// already loaded. It is a no-op if the task has no associated experiments.
+func (m *TaskMetadata) LoadExperiments(ctx context.Context) error {
+	if m.Experiments != nil || len(m.ExperimentIDs) == 0 {
+		return nil
+	}
+
+	var experiments []*model.Experiment
+	if err := db.Bun().NewSelect().Model(&experiments).
+		Where("id IN (?)", bun.In(m.ExperimentIDs)).
+		Scan(ctx); err != nil {
+		return err
+	}
+	m.Experiments = experiments
+	return nil
+}
+
+// LoadTrials populates m.Trials with the trials referenced by the task, if not already loaded.
+// It is a no-op if the task has no associated trials.
+func (m *TaskMetadata) LoadTrials(ctx context.Context) error {
+	if m.Trials != nil || len(m.TrialIDs) == 0 {
+		return nil
+	}
+
+	var trials []*model.Trial
+	if err := db.Bun().NewSelect().Model(&trials).
+		Where("id IN (?)", bun.In(m.TrialIDs)).
+		Scan(ctx); err != nil {
+		return err
+	}
+	m.Trials = trials
+	return nil
+}
+
+// LoadAttributes populates Owner, Workspace, Experiments, and Trials in one call, issuing one
+// query per relation that is not already loaded. Callers that only need a subset of relations
+// should call the individual Load* methods instead.
+func (m *TaskMetadata) LoadAttributes(ctx context.Context) error {
+	if err := m.LoadOwner(ctx); err != nil {
+		return err
+	}
+	if err := m.LoadWorkspace(ctx); err != nil {
+		return err
+	}
+	if err := m.LoadExperiments(ctx); err != nil {
+		return err
+	}
+	if err := m.LoadTrials(ctx); err != nil {
+		return err
+	}
+	return nil
+}
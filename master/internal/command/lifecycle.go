@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/determined-ai/determined/master/internal/db"
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+// LifecycleStatus is the lifecycle state of a persisted command, mirroring the states a command
+// actor moves through from submission to completion.
+type LifecycleStatus string
+
+const (
+	// LifecycleStatusQueued means the command has been submitted but not yet assigned an agent.
+	LifecycleStatusQueued LifecycleStatus = "queued"
+	// LifecycleStatusAssigned means the command has been assigned an agent.
+	LifecycleStatusAssigned LifecycleStatus = "assigned"
+	// LifecycleStatusPulling means the command's image is being pulled.
+	LifecycleStatusPulling LifecycleStatus = "pulling"
+	// LifecycleStatusStarting means the command's container is starting.
+	LifecycleStatusStarting LifecycleStatus = "starting"
+	// LifecycleStatusRunning means the command's container is running.
+	LifecycleStatusRunning LifecycleStatus = "running"
+	// LifecycleStatusTerminating means the command has been asked to terminate.
+	LifecycleStatusTerminating LifecycleStatus = "terminating"
+	// LifecycleStatusTerminated means the command has exited, successfully or not.
+	LifecycleStatusTerminated LifecycleStatus = "terminated"
+	// LifecycleStatusErrored means the command's container exited with an error.
+	LifecycleStatusErrored LifecycleStatus = "errored"
+)
+
+// lifecycleSetClause is one `SET <expr>` clause to apply to command_state, along with the bun
+// query args it binds against the expr's placeholders.
+type lifecycleSetClause struct {
+	expr string
+	args []interface{}
+}
+
+// buildLifecycleSetClauses decides which command_state columns a lifecycle transition touches.
+// It is pure (no DB access) so the status/exit-code/error branching can be unit tested directly.
+func buildLifecycleSetClauses(
+	status LifecycleStatus, exitCode *int, lifecycleErr error, now time.Time,
+) []lifecycleSetClause {
+	clauses := []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{status}},
+	}
+
+	switch status {
+	case LifecycleStatusRunning:
+		clauses = append(clauses,
+			lifecycleSetClause{expr: "start_time = COALESCE(start_time, ?)", args: []interface{}{now}})
+	case LifecycleStatusTerminated, LifecycleStatusErrored:
+		clauses = append(clauses, lifecycleSetClause{expr: "end_time = ?", args: []interface{}{now}})
+		if exitCode != nil {
+			clauses = append(clauses,
+				lifecycleSetClause{expr: "exit_code = ?", args: []interface{}{*exitCode}})
+		}
+	}
+
+	if lifecycleErr != nil {
+		clauses = append(clauses,
+			lifecycleSetClause{expr: "error_message = ?", args: []interface{}{lifecycleErr.Error()}})
+	}
+
+	return clauses
+}
+
+// UpdateCommandLifecycle persists a lifecycle transition for a command. The command actor calls
+// this on every state transition so that command_state reflects status/timing/error information
+// without requiring a join against allocations or tasks to answer it. exitCode should be non-nil
+// only on terminal transitions (LifecycleStatusTerminated / LifecycleStatusErrored) where the
+// container's exit code is known; it is ignored otherwise.
+// Returns db.ErrNotFound if a command with given taskID does not exist.
+func UpdateCommandLifecycle(
+	ctx context.Context,
+	taskID model.TaskID,
+	status LifecycleStatus,
+	exitCode *int,
+	lifecycleErr error,
+) error {
+	query := db.Bun().NewUpdate().Table("command_state").Where("task_id = ?", taskID)
+	for _, clause := range buildLifecycleSetClauses(status, exitCode, lifecycleErr, time.Now()) {
+		query = query.Set(clause.expr, clause.args...)
+	}
+
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLifecycleSetClausesRunningSetsStartTime(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	clauses := buildLifecycleSetClauses(LifecycleStatusRunning, nil, nil, now)
+
+	require.Equal(t, []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{LifecycleStatusRunning}},
+		{expr: "start_time = COALESCE(start_time, ?)", args: []interface{}{now}},
+	}, clauses)
+}
+
+func TestBuildLifecycleSetClausesTerminatedWithExitCode(t *testing.T) {
+	now := time.Unix(0, 0)
+	exitCode := 137
+
+	clauses := buildLifecycleSetClauses(LifecycleStatusTerminated, &exitCode, nil, now)
+
+	require.Equal(t, []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{LifecycleStatusTerminated}},
+		{expr: "end_time = ?", args: []interface{}{now}},
+		{expr: "exit_code = ?", args: []interface{}{exitCode}},
+	}, clauses)
+}
+
+func TestBuildLifecycleSetClausesTerminatedWithoutExitCode(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	clauses := buildLifecycleSetClauses(LifecycleStatusTerminated, nil, nil, now)
+
+	require.Equal(t, []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{LifecycleStatusTerminated}},
+		{expr: "end_time = ?", args: []interface{}{now}},
+	}, clauses)
+}
+
+func TestBuildLifecycleSetClausesErroredSetsErrorMessage(t *testing.T) {
+	now := time.Unix(0, 0)
+	lifecycleErr := errors.New("container exited with an error")
+
+	clauses := buildLifecycleSetClauses(LifecycleStatusErrored, nil, lifecycleErr, now)
+
+	require.Equal(t, []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{LifecycleStatusErrored}},
+		{expr: "end_time = ?", args: []interface{}{now}},
+		{expr: "error_message = ?", args: []interface{}{lifecycleErr.Error()}},
+	}, clauses)
+}
+
+func TestBuildLifecycleSetClausesNonTerminalIgnoresExitCode(t *testing.T) {
+	now := time.Unix(0, 0)
+	exitCode := 1
+
+	clauses := buildLifecycleSetClauses(LifecycleStatusQueued, &exitCode, nil, now)
+
+	require.Equal(t, []lifecycleSetClause{
+		{expr: "status = ?", args: []interface{}{LifecycleStatusQueued}},
+	}, clauses)
+}
@@ -3,6 +3,8 @@ package command
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
@@ -11,6 +13,17 @@ import (
 	"github.com/determined-ai/determined/master/pkg/model"
 )
 
+// ErrMissingTasks is returned by the batch lookup functions in this package when one or more
+// of the requested task IDs could not be resolved against command_state. Callers that can
+// tolerate partial results should inspect TaskIDs rather than treat this as a hard failure.
+type ErrMissingTasks struct {
+	TaskIDs []model.TaskID
+}
+
+func (e ErrMissingTasks) Error() string {
+	return fmt.Sprintf("could not find %d task(s): %v", len(e.TaskIDs), e.TaskIDs)
+}
+
 // GetCommandOwnerID gets a command's ownerID from a taskID. Uses persisted command state.
 // Returns db.ErrNotFound if a command with given taskID does not exist.
 func GetCommandOwnerID(ctx context.Context, taskID model.TaskID) (model.UserID, error) {
@@ -20,7 +33,7 @@ func GetCommandOwnerID(ctx context.Context, taskID model.TaskID) (model.UserID,
 	}{}
 
 	if err := db.Bun().NewSelect().Model(ownerIDBun).
-		ColumnExpr("generic_command_spec->'Base'->'Owner'->'id' AS owner_id").
+		Column("owner_id").
 		Where("task_id = ?", taskID).
 		Scan(ctx); err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
@@ -32,13 +45,80 @@ func GetCommandOwnerID(ctx context.Context, taskID model.TaskID) (model.UserID,
 	return ownerIDBun.OwnerID, nil
 }
 
+// GetCommandOwnerIDs gets the ownerIDs for a batch of taskIDs in a single query. If any of
+// taskIDs could not be resolved, an ErrMissingTasks carrying the unresolved IDs is returned
+// alongside the partial map, consistent with IdentifyTasks.
+func GetCommandOwnerIDs(
+	ctx context.Context, taskIDs []model.TaskID,
+) (map[model.TaskID]model.UserID, error) {
+	if len(taskIDs) == 0 {
+		return map[model.TaskID]model.UserID{}, nil
+	}
+
+	var rows []struct {
+		bun.BaseModel `bun:"table:command_state"`
+		TaskID        model.TaskID `bun:"task_id"`
+		OwnerID       model.UserID `bun:"owner_id"`
+	}
+
+	if err := db.Bun().NewSelect().Model(&rows).
+		Column("task_id", "owner_id").
+		Where("task_id IN (?)", bun.In(taskIDs)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	ownerIDs := make(map[model.TaskID]model.UserID, len(rows))
+	found := make(map[model.TaskID]struct{}, len(rows))
+	for _, row := range rows {
+		ownerIDs[row.TaskID] = row.OwnerID
+		found[row.TaskID] = struct{}{}
+	}
+
+	if missing := missingTaskIDs(taskIDs, found); len(missing) > 0 {
+		return ownerIDs, ErrMissingTasks{TaskIDs: missing}
+	}
+	return ownerIDs, nil
+}
+
 // TaskMetadata captures minimal metadata about a task.
 type TaskMetadata struct {
 	bun.BaseModel `bun:"table:command_state"`
+	TaskID        model.TaskID        `bun:"task_id"`
+	OwnerID       model.UserID        `bun:"owner_id"`
 	WorkspaceID   model.AccessScopeID `bun:"workspace_id"`
 	TaskType      model.TaskType      `bun:"task_type"`
 	ExperimentIDs []int32             `bun:"experiment_ids"`
 	TrialIDs      []int32             `bun:"trial_ids"`
+
+	Status       LifecycleStatus `bun:"status"`
+	StartTime    *time.Time      `bun:"start_time"`
+	EndTime      *time.Time      `bun:"end_time"`
+	ExitCode     *int            `bun:"exit_code"`
+	ErrorMessage string          `bun:"error_message"`
+
+	// Owner, Workspace, Experiments, and Trials are populated on demand by the Load* methods
+	// in eager_load.go. They are left nil until explicitly loaded.
+	Owner       *model.User         `bun:"-"`
+	Workspace   *model.Workspace    `bun:"-"`
+	Experiments []*model.Experiment `bun:"-"`
+	Trials      []*model.Trial      `bun:"-"`
+}
+
+// taskMetadataColumns are the command_state columns backing TaskMetadata. owner_id,
+// workspace_id, and task_type are real typed columns (indexed for filtering/sorting); they used
+// to be extracted from generic_command_spec on every read, which prevented Postgres from using
+// an index. experiment_ids and trial_ids remain JSONB-derived since they aren't filtered on.
+var taskMetadataColumns = []string{
+	"task_id",
+	"owner_id",
+	"workspace_id",
+	"task_type",
+	"status",
+	"start_time",
+	"end_time",
+	"exit_code",
+	"error_message",
 }
 
 // IdentifyTask returns the task metadata for a given task ID.
@@ -46,10 +126,7 @@ type TaskMetadata struct {
 func IdentifyTask(ctx context.Context, taskID model.TaskID) (TaskMetadata, error) {
 	metadata := TaskMetadata{}
 	if err := db.Bun().NewSelect().Model(&metadata).
-		ColumnExpr("generic_command_spec->'Metadata'->'workspace_id' AS workspace_id").
-		// TODO(DET-10004) TaskType needs
-		// to have ->> instead of -> so task_type doesn't get surrounded by double quotes.
-		ColumnExpr("generic_command_spec->'TaskType' as task_type").
+		Column(taskMetadataColumns...).
 		ColumnExpr("generic_command_spec->'Metadata'->'experiment_ids' as experiment_ids").
 		ColumnExpr("generic_command_spec->'Metadata'->'trial_ids' as trial_ids").
 		Where("task_id = ?", taskID).
@@ -61,3 +138,113 @@ func IdentifyTask(ctx context.Context, taskID model.TaskID) (TaskMetadata, error
 	}
 	return metadata, nil
 }
+
+// IdentifyTasks returns the task metadata for a batch of task IDs in a single query. If
+// keepOrder is true, the returned slice is reordered to match the order of taskIDs (dropping any
+// taskIDs that could not be resolved); otherwise results are returned in whatever order Postgres
+// yields them. If any of taskIDs could not be resolved, an ErrMissingTasks carrying the
+// unresolved IDs is returned alongside whatever metadata was found for the rest.
+func IdentifyTasks(
+	ctx context.Context, taskIDs []model.TaskID, keepOrder bool,
+) ([]TaskMetadata, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	var metadata []TaskMetadata
+	if err := db.Bun().NewSelect().Model(&metadata).
+		Column(taskMetadataColumns...).
+		ColumnExpr("generic_command_spec->'Metadata'->'experiment_ids' as experiment_ids").
+		ColumnExpr("generic_command_spec->'Metadata'->'trial_ids' as trial_ids").
+		Where("task_id IN (?)", bun.In(taskIDs)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	metadata, missing := reconcileIdentifiedTasks(taskIDs, metadata, keepOrder)
+	if len(missing) > 0 {
+		return metadata, ErrMissingTasks{TaskIDs: missing}
+	}
+	return metadata, nil
+}
+
+// missingTaskIDs returns the subset of taskIDs that have no entry in found, in the order they
+// appear in taskIDs. Shared by the batch lookup functions in this package so "not found" is
+// reported consistently via ErrMissingTasks rather than silently omitted from a result.
+func missingTaskIDs(taskIDs []model.TaskID, found map[model.TaskID]struct{}) []model.TaskID {
+	var missing []model.TaskID
+	for _, taskID := range taskIDs {
+		if _, ok := found[taskID]; !ok {
+			missing = append(missing, taskID)
+		}
+	}
+	return missing
+}
+
+// reconcileIdentifiedTasks reconciles the rows found for a batch lookup against the requested
+// taskIDs. If keepOrder is true, found is reordered to match taskIDs, dropping any taskIDs that
+// have no corresponding row. It also returns the subset of taskIDs that had no corresponding row,
+// in the order they appear in taskIDs, for the caller to surface via ErrMissingTasks.
+func reconcileIdentifiedTasks(
+	taskIDs []model.TaskID, found []TaskMetadata, keepOrder bool,
+) ([]TaskMetadata, []model.TaskID) {
+	byTaskID := make(map[model.TaskID]TaskMetadata, len(found))
+	presence := make(map[model.TaskID]struct{}, len(found))
+	for _, m := range found {
+		byTaskID[m.TaskID] = m
+		presence[m.TaskID] = struct{}{}
+	}
+
+	missing := missingTaskIDs(taskIDs, presence)
+
+	if keepOrder {
+		ordered := make([]TaskMetadata, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			if m, ok := byTaskID[taskID]; ok {
+				ordered = append(ordered, m)
+			}
+		}
+		found = ordered
+	}
+
+	return found, missing
+}
+
+// Pagination carries the offset/limit the REST layer already uses for list endpoints, so callers
+// of ListCommandsByWorkspace don't have to special-case a one-off offset/limit pair.
+type Pagination struct {
+	Offset int
+	Limit  int
+}
+
+// ListCommandsByWorkspace returns a page of task metadata for commands in a workspace, optionally
+// filtered by task type, along with the total number of matching commands (ignoring pagination).
+// Results are ordered by task_id for stable pagination. Because workspace_id and task_type are
+// indexed columns, this scans and filters in Postgres instead of in Go.
+func ListCommandsByWorkspace(
+	ctx context.Context,
+	workspaceID model.AccessScopeID,
+	taskType model.TaskType,
+	pagination Pagination,
+) ([]TaskMetadata, int, error) {
+	var metadata []TaskMetadata
+	query := db.Bun().NewSelect().Model(&metadata).
+		Column(taskMetadataColumns...).
+		ColumnExpr("generic_command_spec->'Metadata'->'experiment_ids' as experiment_ids").
+		ColumnExpr("generic_command_spec->'Metadata'->'trial_ids' as trial_ids").
+		Where("workspace_id = ?", workspaceID)
+
+	if taskType != "" {
+		query = query.Where("task_type = ?", taskType)
+	}
+
+	total, err := query.Order("task_id").
+		Offset(pagination.Offset).
+		Limit(pagination.Limit).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return metadata, total, nil
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/determined-ai/determined/master/pkg/model"
+)
+
+func TestReconcileIdentifiedTasksKeepOrder(t *testing.T) {
+	taskIDs := []model.TaskID{"a", "b", "c"}
+	found := []TaskMetadata{{TaskID: "c"}, {TaskID: "a"}}
+
+	reconciled, missing := reconcileIdentifiedTasks(taskIDs, found, true)
+
+	require.Equal(t, []model.TaskID{"b"}, missing)
+	require.Len(t, reconciled, 2)
+	require.Equal(t, model.TaskID("a"), reconciled[0].TaskID)
+	require.Equal(t, model.TaskID("c"), reconciled[1].TaskID)
+}
+
+func TestReconcileIdentifiedTasksUnordered(t *testing.T) {
+	taskIDs := []model.TaskID{"a", "b", "c"}
+	found := []TaskMetadata{{TaskID: "c"}, {TaskID: "a"}}
+
+	reconciled, missing := reconcileIdentifiedTasks(taskIDs, found, false)
+
+	require.Equal(t, []model.TaskID{"b"}, missing)
+	require.Equal(t, found, reconciled)
+}
+
+func TestReconcileIdentifiedTasksNoneMissing(t *testing.T) {
+	taskIDs := []model.TaskID{"a", "b"}
+	found := []TaskMetadata{{TaskID: "b"}, {TaskID: "a"}}
+
+	reconciled, missing := reconcileIdentifiedTasks(taskIDs, found, true)
+
+	require.Empty(t, missing)
+	require.Equal(t, []model.TaskID{"a", "b"}, []model.TaskID{reconciled[0].TaskID, reconciled[1].TaskID})
+}
+
+func TestMissingTaskIDs(t *testing.T) {
+	taskIDs := []model.TaskID{"a", "b", "c"}
+	found := map[model.TaskID]struct{}{"a": {}, "c": {}}
+
+	require.Equal(t, []model.TaskID{"b"}, missingTaskIDs(taskIDs, found))
+}
+
+func TestMissingTaskIDsNoneMissing(t *testing.T) {
+	taskIDs := []model.TaskID{"a", "b"}
+	found := map[model.TaskID]struct{}{"a": {}, "b": {}}
+
+	require.Empty(t, missingTaskIDs(taskIDs, found))
+}